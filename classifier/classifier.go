@@ -3,9 +3,11 @@ package classifier
 
 import (
 	"log"
+	"math"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/xmdhs/bayesian-classifier/storage"
@@ -14,17 +16,42 @@ import (
 
 // Classifier is a bayesian classifier, provide training score categorize methods and http api.
 type Classifier struct {
-	segmenter     *util.Segmenter      // 分词器
+	segmenter     util.Tokenizer       // 分词器
 	defaultProb   float64              // 单词在某一分类中出现的默认概率（不存在时）
 	defaultWeight float64              // 默认概率的权重
 	debug         bool                 // 是否开启调试
-	storage       *storage.FileStorage // 存储引擎
-	data          *data                // 存储数据
+	scorer        string               // 分类使用的打分方式："bayes" 或 "fisher"
+	storage       storage.Storage      // 存储引擎
+
+	words      *shardedWords   // 单词数据，按分片存储，支持并发读写
+	categorys  *categoryCounts // 分类数据，使用原子计数器
+	paramsMu   sync.RWMutex    // 保护 thresholds、minimums
+	thresholds map[string]float64
+	minimums   map[string]float64
 }
 
+// data 是导出 / 导入训练数据时使用的快照结构
 type data struct {
-	Categorys map[string]float64            `json:"category"` // 分类数据
-	Words     map[string]map[string]float64 `json:"words"`    // 单词数据
+	Categorys  map[string]float64            `json:"category"`   // 分类数据
+	Words      map[string]map[string]float64 `json:"words"`      // 单词数据
+	Thresholds map[string]float64            `json:"thresholds"` // 朴素贝叶斯下每个分类相对次优分类所需超出的倍数阈值
+	Minimums   map[string]float64            `json:"minimums"`   // Fisher 方法下每个分类的最低接受概率
+}
+
+// Doc 表示一条训练样本
+type Doc struct {
+	Text     string
+	Category string
+}
+
+// defaultJiebaDict 是历史版本硬编码使用的 jieba 词典路径，
+// 在配置未提供 segmenterDict 时作为回退，保持旧配置可以不声明分词器直接工作
+var defaultJiebaDict = map[string]string{
+	"dict":      "dict/jieba.dict.utf8",
+	"hmm":       "dict/hmm_model.utf8",
+	"user":      "dict/user.dict.utf8",
+	"idf":       "dict/idf.utf8",
+	"stopWords": "dict/stop_words.utf8",
 }
 
 // NewClassifier 实例化一个分类器
@@ -35,30 +62,75 @@ func NewClassifier(config map[string]interface{}) *Classifier {
 	t.defaultProb = config["defaultProb"].(float64)
 	t.defaultWeight = config["defaultWeight"].(float64)
 	t.debug = config["debug"].(bool)
+	if scorer, ok := config["scorer"].(string); ok && scorer != "" {
+		t.scorer = scorer
+	} else {
+		t.scorer = "bayes"
+	}
 
 	// 初始化数据结构
-	t.data = new(data)
-	t.data.Categorys = make(map[string]float64)
-	t.data.Words = make(map[string]map[string]float64)
+	t.words = newShardedWords()
+	t.categorys = newCategoryCounts()
+	t.thresholds = make(map[string]float64)
+	t.minimums = make(map[string]float64)
 
 	// 初始化存储器
 	var err error
 	storageConfig := config["storage"].(map[string]string)
-	t.storage, err = storage.NewFileStorage(storageConfig["path"])
+	switch driver := storageConfig["driver"]; driver {
+	case "bolt":
+		t.storage, err = storage.NewBoltStorage(storageConfig["path"])
+	case "file", "":
+		t.storage, err = storage.NewFileStorage(storageConfig["path"])
+	default:
+		log.Fatalln("未知的存储引擎类型：", driver)
+	}
 	if err != nil {
 		log.Fatalln("存储器初始化失败：", err)
 	}
 
 	// 初始化分词器
-	t.segmenter = util.NewSegmenter()
+	// 优先使用调用方直接注入的分词器，便于测试或处理非中文语料
+	if tokenizer, ok := config["tokenizer"].(util.Tokenizer); ok {
+		t.segmenter = tokenizer
+	} else {
+		segmenterName, _ := config["segmenter"].(string)
+		switch segmenterName {
+		case "whitespace":
+			t.segmenter = util.NewWhitespaceTokenizer()
+		case "gse":
+			dictPaths, _ := config["segmenterDictPaths"].([]string)
+			gseSegmenter, err := util.NewGseSegmenter(dictPaths...)
+			if err != nil {
+				log.Fatalln("分词器初始化失败：", err)
+			}
+			t.segmenter = gseSegmenter
+		case "jieba", "":
+			// segmenterDict 缺省时回退到仓库自带的默认词典路径，
+			// 兼容历史上不配置 segmenter/segmenterDict 的调用方
+			dict, _ := config["segmenterDict"].(map[string]string)
+			if dict == nil {
+				dict = defaultJiebaDict
+			}
+			t.segmenter = util.NewJiebaSegmenter(dict["dict"], dict["hmm"], dict["user"], dict["idf"], dict["stopWords"])
+		default:
+			log.Fatalln("未知的分词器类型：", segmenterName)
+		}
+	}
 
 	// 加载数据
 	log.Println("加载数据", storageConfig["path"])
 	t.Import()
 
 	// 自动保存数据
+	// 增量存储引擎（如 BoltStorage）在 Training 时已经通过
+	// IncrWord/IncrCategory 直接落盘（Untrain 只回退内存中的计数），不需要也不应该再定期做一次完整的
+	// Export（那会重新清空并重写全部 bucket）；这类引擎只需要在真正需要
+	// 完整快照时，通过 POST /v1/snapshot 按需触发一次 Export
 	frequency, _ := strconv.Atoi(storageConfig["frequency"])
-	if frequency > 0 {
+	if frequency > 0 && t.storage.Incremental() {
+		log.Println("存储引擎已支持增量持久化，跳过定时自动保存，如需完整快照请调用 /v1/snapshot")
+	} else if frequency > 0 {
 		log.Println("开启自动数据自动保存")
 		go func() {
 			var err error
@@ -85,15 +157,38 @@ func NewClassifier(config map[string]interface{}) *Classifier {
 		log.Println("Http服务未启动")
 	}
 
-	log.Println("初始化完成.\n")
+	log.Println("初始化完成.")
 	return t
 }
 
 // Training 训练分类器
 func (t *Classifier) Training(doc, category string) {
 	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		log.Println("提供了空文档")
+		return
+	}
+	t.TrainingTokens(t.segmenter.Segment(doc), category)
+}
+
+// TrainingwithSlash 使用 "/" 分隔的预分词文本训练分类器
+func (t *Classifier) TrainingwithSlash(doc, category string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		log.Println("提供了空文档")
+		return
+	}
+	t.TrainingTokens(strings.Split(doc, "/"), category)
+}
+
+// TrainingTokens 使用调用方已完成分词的单词列表训练分类器，跳过分词阶段
+// 可用于分词逻辑已在上游完成，或希望绕过 Tokenizer 直接训练的场景
+// 单词与分类计数均为分片 / 原子存储，可与其他训练调用并发执行。
+// 同时增量写入存储引擎，使用 BoltStorage 时无需等待自动保存即可落盘，
+// 也不必在每次保存时把整个模型重新序列化一遍
+func (t *Classifier) TrainingTokens(words []string, category string) {
 	category = strings.TrimSpace(category)
-	if doc == "" || category == "" {
+	if len(words) == 0 || category == "" {
 		log.Println("提供了空文档")
 		return
 	}
@@ -101,25 +196,56 @@ func (t *Classifier) Training(doc, category string) {
 	// 更新单词数据
 	// 同一个文档中单词出现多次，仅记录一次
 	fwords := make(map[string]bool)
-	words := t.segmenter.Segment(doc)
 	for _, word := range words {
 		if _, ok := fwords[word]; ok {
 			continue
 		}
 		fwords[word] = true
-		if _, ok := t.data.Words[word]; !ok {
-			t.data.Words[word] = make(map[string]float64)
+		t.words.incr(word, category, 1)
+		if err := t.storage.IncrWord(word, category, 1); err != nil {
+			log.Println("增量写入单词数据失败：", err)
 		}
-		t.data.Words[word][category]++
 		log.Println("单词训练：", word)
 	}
 	// 更新分类统计
-	t.data.Categorys[category]++
+	t.categorys.incr(category, 1)
+	if err := t.storage.IncrCategory(category, 1); err != nil {
+		log.Println("增量写入分类数据失败：", err)
+	}
+}
+
+// TrainingBatch 将一批训练样本并发地分发给 runtime.GOMAXPROCS 个 worker 进行训练
+// 单词与分类计数都改为分片 + 原子存储后，多个 worker 可以安全地并发写入同一模型
+func (t *Classifier) TrainingBatch(docs []Doc) {
+	if len(docs) == 0 {
+		return
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(docs) {
+		workers = len(docs)
+	}
 
-	return
+	jobs := make(chan Doc)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for d := range jobs {
+				t.Training(d.Text, d.Category)
+			}
+		}()
+	}
+	for _, d := range docs {
+		jobs <- d
+	}
+	close(jobs)
+	wg.Wait()
 }
 
-func (t *Classifier) TrainingwithSlash(doc, category string) {
+// Untrain 撤销一条此前训练过的样本，用于纠正错误标注的数据。
+// 只会回退内存中的计数，存储引擎上的增量计数由下一次 Export 全量覆盖校正
+func (t *Classifier) Untrain(doc, category string) {
 	doc = strings.TrimSpace(doc)
 	category = strings.TrimSpace(category)
 	if doc == "" || category == "" {
@@ -127,31 +253,83 @@ func (t *Classifier) TrainingwithSlash(doc, category string) {
 		return
 	}
 
-	// 更新单词数据
-	// 同一个文档中单词出现多次，仅记录一次
 	fwords := make(map[string]bool)
-	words := strings.Split(doc, "/")
+	words := t.segmenter.Segment(doc)
 	for _, word := range words {
-		if _, ok := fwords[word]; ok {
+		if fwords[word] {
 			continue
 		}
 		fwords[word] = true
-		if _, ok := t.data.Words[word]; !ok {
-			t.data.Words[word] = make(map[string]float64)
-		}
-		t.data.Words[word][category]++
-		log.Println("单词训练：", word)
+		t.words.decr(word, category, 1)
 	}
-	// 更新分类统计
-	t.data.Categorys[category]++
+	t.categorys.decr(category, 1)
+}
+
+// RemoveCategory 整体移除一个分类及其全部训练数据
+func (t *Classifier) RemoveCategory(category string) {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return
+	}
+	t.words.deleteCategory(category)
+	t.categorys.delete(category)
+}
+
+// CategoryStats 描述单个分类的统计信息
+type CategoryStats struct {
+	Docs    float64 `json:"docs"`    // 该分类训练过的文档数
+	Entropy float64 `json:"entropy"` // 该分类下单词分布的香农熵（以 2 为底）
+}
+
+// Stats 描述分类器当前的整体统计信息
+type Stats struct {
+	VocabSize int                      `json:"vocabSize"` // 词汇表大小
+	DocCount  float64                  `json:"docCount"`  // 已训练的文档总数
+	Categorys map[string]CategoryStats `json:"categorys"` // 各分类的统计信息
+}
+
+// Stats 返回词汇量、各分类文档数，以及各分类单词分布的香农熵
+// 熵越低，说明该分类下的单词分布越集中、越容易被区分
+func (t *Classifier) Stats() Stats {
+	categorys := t.categorys.snapshot()
+	docCount := 0.0
+	for _, n := range categorys {
+		docCount += n
+	}
+
+	wordTotal := make(map[string]float64, len(categorys))
+	vocab := 0
+	t.words.forEach(func(word string, counts map[string]uint64) {
+		vocab++
+		for category, n := range counts {
+			wordTotal[category] += float64(n)
+		}
+	})
 
-	return
+	entropy := make(map[string]float64, len(categorys))
+	t.words.forEach(func(word string, counts map[string]uint64) {
+		for category, n := range counts {
+			total := wordTotal[category]
+			if total == 0 || n == 0 {
+				continue
+			}
+			p := float64(n) / total
+			entropy[category] -= p * math.Log2(p)
+		}
+	})
+
+	stats := Stats{VocabSize: vocab, DocCount: docCount, Categorys: make(map[string]CategoryStats, len(categorys))}
+	for category, docs := range categorys {
+		stats.Categorys[category] = CategoryStats{Docs: docs, Entropy: entropy[category]}
+	}
+	return stats
 }
 
 // Score 查看一个单词的概率分布
 func (t *Classifier) Score(word, category string) []*ScoreItem {
 	scores := NewScores()
-	if _, ok := t.data.Words[word]; !ok {
+	counts := t.words.get(word)
+	if counts == nil {
 		return scores.GetSlice()
 	}
 
@@ -160,7 +338,7 @@ func (t *Classifier) Score(word, category string) []*ScoreItem {
 		scores.Append(category, t.wordWeightProb(word, category, t.defaultWeight, t.defaultProb))
 	} else {
 		// 计算所有分类
-		for category = range t.data.Words[word] {
+		for category = range counts {
 			scores.Append(category, t.wordWeightProb(word, category, t.defaultWeight, t.defaultProb))
 		}
 	}
@@ -169,11 +347,12 @@ func (t *Classifier) Score(word, category string) []*ScoreItem {
 
 // 单词在指定分类所有文档中出现的概率为
 func (t *Classifier) wordProb(word, category string) float64 {
-	if _, ok := t.data.Words[word]; !ok {
+	counts := t.words.get(word)
+	if counts == nil {
 		return 0.0
 	}
-	if num, ok := t.data.Words[word][category]; ok {
-		return num / t.data.Categorys[category]
+	if num, ok := counts[category]; ok {
+		return float64(num) / float64(t.categorys.get(category))
 	}
 	return 0.0
 }
@@ -186,8 +365,8 @@ func (t *Classifier) wordWeightProb(word, category string, weight, assumedprob f
 	basicProb := t.wordProb(word, category)
 	// 统计单词在所有分类中出现的次数
 	var total float64
-	for _, num := range t.data.Words[word] {
-		total += num
+	for _, num := range t.words.get(word) {
+		total += float64(num)
 	}
 	// 计算加权平均概率
 	return ((weight * assumedprob) + (total * basicProb)) / (weight + total)
@@ -195,13 +374,82 @@ func (t *Classifier) wordWeightProb(word, category string, weight, assumedprob f
 
 // Categorize 对文档分类
 // P(category|document) = P(document|category) * P(category) / P(document)
+//
+// scorer 为 "fisher" 时直接返回 FisherCategorize 的结果；否则调用 LogCategorize
+// 并通过 log-sum-exp 技巧还原为概率：
+// p_i = exp(l_i - max_l) / Σ exp(l_j - max_l)，避免长文档下的下溢问题，
+// 同时保证返回的概率之和为 1；最佳分类未通过 passesThreshold 判定时返回空列表。
 func (t *Classifier) Categorize(doc string) []*ScoreItem {
+	if t.scorer == "fisher" {
+		return t.FisherCategorize(doc)
+	}
+	return t.bayesCategorize(doc)
+}
+
+// bayesCategorize 使用朴素贝叶斯对文档分类
+func (t *Classifier) bayesCategorize(doc string) []*ScoreItem {
+	logScores, _ := t.LogCategorize(doc)
+	if len(logScores) == 0 {
+		return logScores
+	}
+
+	maxLog := logScores[0].Score
+	for _, s := range logScores {
+		if s.Score > maxLog {
+			maxLog = s.Score
+		}
+	}
+
+	exps := make([]float64, len(logScores))
+	sum := 0.0
+	for i, s := range logScores {
+		exps[i] = math.Exp(s.Score - maxLog)
+		sum += exps[i]
+	}
+
+	scores := NewScores()
+	for i, s := range logScores {
+		scores.Append(s.Category, exps[i]/sum)
+	}
+	ranked := scores.Top(10)
+	if !t.passesThreshold(ranked) {
+		return NewScores().GetSlice()
+	}
+	return ranked
+}
+
+// passesThreshold 实现 PCI 风格的朴素贝叶斯阈值判定：只有当最佳分类的概率
+// 超过次优分类概率的 getThreshold(best) 倍时，才认为这次分类结果足够可信
+func (t *Classifier) passesThreshold(ranked []*ScoreItem) bool {
+	if len(ranked) < 2 {
+		return len(ranked) == 1
+	}
+	best, runnerUp := ranked[0], ranked[1]
+	if runnerUp.Score == 0 {
+		return true
+	}
+	return best.Score > runnerUp.Score*t.getThreshold(best.Category)
+}
+
+// LogCategorize 在对数空间对文档分类
+// P(category|document) 的对数为 ln P(document|category) + ln P(category)，
+// 返回的 Score 为未归一化的对数似然。相比 docProb 直接相乘各单词概率，
+// 对数求和能够避免文档较长时乘积下溢为 0 的问题。
+// 第二个返回值表示是否存在某个分类按朴素乘法计算会下溢为 0。
+func (t *Classifier) LogCategorize(doc string) ([]*ScoreItem, bool) {
 	scores := NewScores()
+	categorys := t.categorys.snapshot()
 	total := t.categoryNumTotal()
-	for cate := range t.data.Categorys {
-		scores.Append(cate, t.docProb(doc, cate)*t.data.Categorys[cate]/total)
+	underflowAvoided := false
+	for cate, n := range categorys {
+		logProb, avoided := t.LogScore(doc, cate)
+		if avoided {
+			underflowAvoided = true
+		}
+		logProb += math.Log(n / total)
+		scores.Append(cate, logProb)
 	}
-	return scores.Top(10)
+	return scores.Top(10), underflowAvoided
 }
 
 // docProb 整篇文档的概率计算
@@ -217,10 +465,138 @@ func (t *Classifier) docProb(doc, category string) float64 {
 	return prob
 }
 
+// LogScore 在对数空间计算整篇文档属于指定分类的概率
+// ln P(document|category) = ln P(word1|category) + ln P(word2|category) + ...
+// 第二个返回值表示该对数值按朴素乘法计算是否会下溢为 0。
+func (t *Classifier) LogScore(doc, category string) (float64, bool) {
+	logProb := 0.0
+	words := t.segmenter.Segment(doc)
+	for _, word := range words {
+		wp := t.wordWeightProb(word, category, t.defaultWeight, t.defaultProb)
+		logProb += math.Log(wp)
+	}
+	// 按朴素乘法计算时，对数小于 math.SmallestNonzeroFloat64 的对数意味着乘积已下溢为 0
+	underflowAvoided := logProb < math.Log(math.SmallestNonzeroFloat64)
+	return logProb, underflowAvoided
+}
+
+// FisherCategorize 使用 Fisher 方法（出自《集体智慧编程》）对文档分类
+// 与朴素贝叶斯不同，Fisher 方法为每个分类计算一个可与其他分类独立比较的概率，
+// 并通过 Minimums 为每个分类设置最低接受概率，低于该值的分类不会被采纳
+func (t *Classifier) FisherCategorize(doc string) []*ScoreItem {
+	scores := NewScores()
+	for _, cate := range t.categorys.names() {
+		p := t.FisherProb(doc, cate)
+		if p > t.getMinimum(cate) {
+			scores.Append(cate, p)
+		}
+	}
+	return scores.Top(10)
+}
+
+// FisherProb 使用 Fisher 方法计算文档属于指定分类的概率
+// fscore = -2 * Σ ln(cprob_i)，再通过自由度为 2*len(words) 的卡方分布的
+// 逆累积分布函数换算为分类概率
+func (t *Classifier) FisherProb(doc, category string) float64 {
+	words := t.segmenter.Segment(doc)
+	// 在对数空间累加，避免文档单词数较多时 Σ ln(cprob_i) 对应的乘积下溢为 0
+	// （与 LogScore 避免 docProb 下溢的做法相同）
+	logP := 0.0
+	n := 0
+	for _, word := range words {
+		cprob := t.weightedCategoryProb(word, category)
+		if cprob <= 0 {
+			continue
+		}
+		logP += math.Log(cprob)
+		n++
+	}
+	if n == 0 {
+		return 0.0
+	}
+	fscore := -2 * logP
+	return invchi2(fscore, n*2)
+}
+
+// wordCategoryProb 计算 P(category|word)，即单词属于指定分类的条件概率
+func (t *Classifier) wordCategoryProb(word, category string) float64 {
+	counts := t.words.get(word)
+	if counts == nil {
+		return 0.0
+	}
+	var total float64
+	for _, num := range counts {
+		total += float64(num)
+	}
+	if total == 0 {
+		return 0.0
+	}
+	return float64(counts[category]) / total
+}
+
+// weightedCategoryProb 对 wordCategoryProb 施加与 wordWeightProb 相同的假定概率加权，
+// 避免仅出现过一两次的单词主导 Fisher 计算结果
+func (t *Classifier) weightedCategoryProb(word, category string) float64 {
+	basicProb := t.wordCategoryProb(word, category)
+	var total float64
+	for _, num := range t.words.get(word) {
+		total += float64(num)
+	}
+	return ((t.defaultWeight * t.defaultProb) + (total * basicProb)) / (t.defaultWeight + total)
+}
+
+// invchi2 自由度为 df 的卡方分布逆累积分布函数的近似实现，
+// 写法来自《集体智慧编程》中 Fisher 判别法一节
+func invchi2(chi float64, df int) float64 {
+	m := chi / 2.0
+	sum := math.Exp(-m)
+	term := sum
+	for i := 1; i < df/2; i++ {
+		term *= m / float64(i)
+		sum += term
+	}
+	return math.Min(sum, 1.0)
+}
+
+// SetThreshold 设置朴素贝叶斯下指定分类相对次优分类所需超出的倍数阈值
+func (t *Classifier) SetThreshold(category string, threshold float64) {
+	t.paramsMu.Lock()
+	defer t.paramsMu.Unlock()
+	t.thresholds[category] = threshold
+}
+
+// getThreshold 获取朴素贝叶斯下指定分类的倍数阈值，未设置时默认为 1.0
+// （即最佳分类的概率须严格高于次优分类，两者相等时不通过）
+func (t *Classifier) getThreshold(category string) float64 {
+	t.paramsMu.RLock()
+	defer t.paramsMu.RUnlock()
+	if threshold, ok := t.thresholds[category]; ok {
+		return threshold
+	}
+	return 1.0
+}
+
+// SetMinimum 设置 Fisher 方法下指定分类的最低接受概率
+func (t *Classifier) SetMinimum(category string, min float64) {
+	t.paramsMu.Lock()
+	defer t.paramsMu.Unlock()
+	t.minimums[category] = min
+}
+
+// getMinimum 获取 Fisher 方法下指定分类的最低接受概率，未设置时默认为 0
+func (t *Classifier) getMinimum(category string) float64 {
+	t.paramsMu.RLock()
+	defer t.paramsMu.RUnlock()
+	if min, ok := t.minimums[category]; ok {
+		return min
+	}
+	return 0.0
+}
+
 // categoryNumTotal 获取所有单词训练的数量
 func (t *Classifier) categoryNumTotal() float64 {
 	total := 0.0
-	for _, n := range t.data.Categorys {
+	for _, n := range t.categorys.snapshot() {
 		total += n
 	}
 	return total
@@ -228,15 +604,56 @@ func (t *Classifier) categoryNumTotal() float64 {
 
 // Categorys 获取所有的分类数据
 func (t *Classifier) Categorys() map[string]float64 {
-	return t.data.Categorys
+	return t.categorys.snapshot()
 }
 
-// Export 导出训练数据
+// Export 导出训练数据：逐个分片在读锁下复制单词数据取快照，复制某个分片期间
+// 会阻塞对该分片的写入，其他分片的写入不受影响；各分片与分类计数不是在同一时刻取得的
 func (t *Classifier) Export() error {
-	return t.storage.Save(t.data)
+	t.paramsMu.RLock()
+	thresholds := make(map[string]float64, len(t.thresholds))
+	for k, v := range t.thresholds {
+		thresholds[k] = v
+	}
+	minimums := make(map[string]float64, len(t.minimums))
+	for k, v := range t.minimums {
+		minimums[k] = v
+	}
+	t.paramsMu.RUnlock()
+
+	snapshot := &data{
+		Categorys:  t.categorys.snapshot(),
+		Words:      t.words.snapshot(),
+		Thresholds: thresholds,
+		Minimums:   minimums,
+	}
+	return t.storage.Save(snapshot)
 }
 
 // Import 导入训练数据
 func (t *Classifier) Import() error {
-	return t.storage.Load(t.data)
+	d := new(data)
+	if err := t.storage.Load(d); err != nil {
+		return err
+	}
+
+	for category, n := range d.Categorys {
+		t.categorys.incr(category, uint64(n))
+	}
+	for word, counts := range d.Words {
+		for category, n := range counts {
+			t.words.incr(word, category, uint64(n))
+		}
+	}
+
+	t.paramsMu.Lock()
+	for category, threshold := range d.Thresholds {
+		t.thresholds[category] = threshold
+	}
+	for category, min := range d.Minimums {
+		t.minimums[category] = min
+	}
+	t.paramsMu.Unlock()
+
+	return nil
 }
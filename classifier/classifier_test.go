@@ -0,0 +1,90 @@
+package classifier
+
+import (
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/xmdhs/bayesian-classifier/util"
+)
+
+// newTestClassifier 实例化一个用于测试的分类器：文件存储指向临时目录，
+// 分词器换成 WhitespaceTokenizer，避免依赖 gojieba/gse 这类 CGO 分词器
+func newTestClassifier(t *testing.T, scorer string) *Classifier {
+	t.Helper()
+	config := map[string]interface{}{
+		"defaultProb":   0.5,
+		"defaultWeight": 1.0,
+		"debug":         false,
+		"scorer":        scorer,
+		"storage": map[string]string{
+			"driver": "file",
+			"path":   filepath.Join(t.TempDir(), "model.json"),
+		},
+		"tokenizer": util.NewWhitespaceTokenizer(),
+		"http":      false,
+	}
+	return NewClassifier(config)
+}
+
+// TestCategorizeProbabilitiesSumToOne 验证 log-sum-exp 还原出的概率之和为 1，
+// 覆盖 chunk0-1 引入的 LogCategorize/bayesCategorize 路径
+func TestCategorizeProbabilitiesSumToOne(t *testing.T) {
+	c := newTestClassifier(t, "bayes")
+	for i := 0; i < 20; i++ {
+		c.Training("quick brown fox jumps over lazy dog", "animal")
+		c.Training("stock market bond yield interest rate finance", "finance")
+	}
+
+	scores := c.Categorize("fox dog jumps over")
+	if len(scores) == 0 {
+		t.Fatalf("Categorize returned no scores")
+	}
+
+	sum := 0.0
+	for _, s := range scores {
+		if math.IsNaN(s.Score) {
+			t.Fatalf("Categorize returned NaN score for category %q", s.Category)
+		}
+		sum += s.Score
+	}
+	if math.Abs(sum-1.0) > 1e-9 {
+		t.Errorf("Categorize scores sum to %v, want 1.0", sum)
+	}
+}
+
+// TestTrainingBatchConcurrent 并发训练不同分类，验证 shardedWords/categoryCounts
+// 在并发写入下（配合 -race）不丢计数、不触发数据竞争，覆盖 chunk0-4 引入的分片存储
+func TestTrainingBatchConcurrent(t *testing.T) {
+	c := newTestClassifier(t, "bayes")
+
+	const goroutines = 8
+	const docsPerGoroutine = 50
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			category := "even"
+			if g%2 != 0 {
+				category = "odd"
+			}
+			docs := make([]Doc, 0, docsPerGoroutine)
+			for i := 0; i < docsPerGoroutine; i++ {
+				docs = append(docs, Doc{Text: "concurrent training sample document", Category: category})
+			}
+			c.TrainingBatch(docs)
+		}(g)
+	}
+	wg.Wait()
+
+	categorys := c.Categorys()
+	wantPerCategory := float64(goroutines / 2 * docsPerGoroutine)
+	for _, category := range []string{"even", "odd"} {
+		if got := categorys[category]; got != wantPerCategory {
+			t.Errorf("category %q doc count = %v, want %v", category, got, wantPerCategory)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package classifier
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTP 提供基于 net/http 的 REST API，底层调用 Classifier 完成训练、分类与模型管理
+type HTTP struct {
+	addr       string
+	classifier *Classifier
+	mux        *http.ServeMux
+	middleware func(http.Handler) http.Handler
+}
+
+// NewHTTP 实例化 HTTP 服务，port 形如 ":8080"
+func NewHTTP(port string, t *Classifier) *HTTP {
+	h := &HTTP{addr: port, classifier: t, mux: http.NewServeMux()}
+	h.middleware = loggingMiddleware
+	h.routes()
+	return h
+}
+
+// SetAuthMiddleware 设置鉴权中间件，包裹在请求日志中间件之内，
+// 在路由匹配、处理请求之前执行
+func (h *HTTP) SetAuthMiddleware(auth func(http.Handler) http.Handler) {
+	h.middleware = func(next http.Handler) http.Handler {
+		return loggingMiddleware(auth(next))
+	}
+}
+
+// Start 启动 HTTP 服务，会一直阻塞直到服务退出
+func (h *HTTP) Start() error {
+	return http.ListenAndServe(h.addr, h.middleware(h.mux))
+}
+
+// loggingMiddleware 记录每个请求的方法、路径与耗时
+func loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Println(r.Method, r.URL.Path, time.Since(start))
+	})
+}
+
+func (h *HTTP) routes() {
+	h.mux.HandleFunc("/v1/train", h.handleTrain)
+	h.mux.HandleFunc("/v1/untrain", h.handleUntrain)
+	h.mux.HandleFunc("/v1/classify", h.handleClassify)
+	h.mux.HandleFunc("/v1/categories", h.handleCategories)
+	h.mux.HandleFunc("/v1/categories/", h.handleRemoveCategory)
+	h.mux.HandleFunc("/v1/words/", h.handleWord)
+	h.mux.HandleFunc("/v1/snapshot", h.handleSnapshot)
+	h.mux.HandleFunc("/v1/stats", h.handleStats)
+}
+
+// sample 是 /v1/train、/v1/untrain 请求体中的单条训练样本
+type sample struct {
+	Doc      string `json:"doc"`
+	Category string `json:"category"`
+}
+
+// batchRequest 是 /v1/train、/v1/untrain 的请求体：一批训练样本
+type batchRequest struct {
+	Docs []sample `json:"docs"`
+}
+
+// handleTrain 处理 POST /v1/train，批量训练分类器
+func (h *HTTP) handleTrain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	docs := make([]Doc, 0, len(req.Docs))
+	for _, s := range req.Docs {
+		docs = append(docs, Doc{Text: s.Doc, Category: s.Category})
+	}
+	h.classifier.TrainingBatch(docs)
+	writeJSON(w, map[string]int{"trained": len(docs)})
+}
+
+// handleUntrain 处理 POST /v1/untrain，用于纠正错误标注的样本
+func (h *HTTP) handleUntrain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, s := range req.Docs {
+		h.classifier.Untrain(s.Doc, s.Category)
+	}
+	writeJSON(w, map[string]int{"untrained": len(req.Docs)})
+}
+
+// classifyRequest 是 POST /v1/classify 的请求体
+type classifyRequest struct {
+	Doc string `json:"doc"`
+}
+
+// classifyResponse 同时返回朴素贝叶斯与 Fisher 两种打分方式的结果
+type classifyResponse struct {
+	Bayes  []*ScoreItem `json:"bayes"`
+	Fisher []*ScoreItem `json:"fisher"`
+}
+
+// handleClassify 处理 POST /v1/classify，返回两种打分方式的排序结果
+func (h *HTTP) handleClassify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req classifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, classifyResponse{
+		Bayes:  h.classifier.bayesCategorize(req.Doc),
+		Fisher: h.classifier.FisherCategorize(req.Doc),
+	})
+}
+
+// handleCategories 处理 GET /v1/categories，列出所有分类及其文档数
+func (h *HTTP) handleCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, h.classifier.Categorys())
+}
+
+// handleRemoveCategory 处理 DELETE /v1/categories/{name}，整类撤销训练数据
+func (h *HTTP) handleRemoveCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/v1/categories/")
+	if name == "" {
+		http.Error(w, "missing category name", http.StatusBadRequest)
+		return
+	}
+	h.classifier.RemoveCategory(name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleWord 处理 GET /v1/words/{word}，返回该单词在各分类下的概率分布
+func (h *HTTP) handleWord(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	word := strings.TrimPrefix(r.URL.Path, "/v1/words/")
+	if word == "" {
+		http.Error(w, "missing word", http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, h.classifier.Score(word, ""))
+}
+
+// handleSnapshot 处理 POST /v1/snapshot，强制立即导出一次完整训练数据。
+// 增量存储引擎（如 BoltStorage）不会启动定时自动保存，这个接口是它们
+// 获得完整快照（迁移、备份）的唯一途径
+func (h *HTTP) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := h.classifier.Export(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleStats 处理 GET /v1/stats，返回词汇量、文档数与各分类的熵
+func (h *HTTP) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, h.classifier.Stats())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("响应编码失败：", err)
+	}
+}
@@ -0,0 +1,43 @@
+package classifier
+
+import "sort"
+
+// ScoreItem 表示一个分类及其对应的得分。得分的含义取决于调用方：
+// 可能是朴素贝叶斯 / Fisher 方法下的概率，也可能是对数似然
+type ScoreItem struct {
+	Category string  `json:"category"`
+	Score    float64 `json:"score"`
+}
+
+// Scores 收集一批 ScoreItem，并支持按得分降序取出前 N 个
+type Scores struct {
+	items []*ScoreItem
+}
+
+// NewScores 实例化一个空的 Scores
+func NewScores() *Scores {
+	return &Scores{items: make([]*ScoreItem, 0)}
+}
+
+// Append 追加一条分类得分
+func (s *Scores) Append(category string, score float64) {
+	s.items = append(s.items, &ScoreItem{Category: category, Score: score})
+}
+
+// GetSlice 返回当前收集到的全部 ScoreItem，不做排序
+func (s *Scores) GetSlice() []*ScoreItem {
+	return s.items
+}
+
+// Top 按得分从高到低排序后返回前 n 条，总数不足 n 条时返回全部
+func (s *Scores) Top(n int) []*ScoreItem {
+	sorted := make([]*ScoreItem, len(s.items))
+	copy(sorted, s.items)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+	if n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
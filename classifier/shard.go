@@ -0,0 +1,217 @@
+package classifier
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// wordShardCount 是单词存储被切分成的分片数量，分片越多，并发训练时的锁争用越小
+const wordShardCount = 32
+
+// wordShard 是分片存储中的一个分片，拥有独立的读写锁
+type wordShard struct {
+	mu    sync.RWMutex
+	words map[string]map[string]uint64
+}
+
+// shardedWords 将单词计数按 fnv32(word) % wordShardCount 分散到多个分片中存储，
+// 使得训练不同单词的 goroutine 之间不会相互阻塞
+type shardedWords struct {
+	shards [wordShardCount]*wordShard
+}
+
+// newShardedWords 实例化一个空的分片单词存储
+func newShardedWords() *shardedWords {
+	sw := &shardedWords{}
+	for i := range sw.shards {
+		sw.shards[i] = &wordShard{words: make(map[string]map[string]uint64)}
+	}
+	return sw
+}
+
+// shardFor 依据单词的 fnv32 哈希选择其所属的分片
+func (sw *shardedWords) shardFor(word string) *wordShard {
+	h := fnv.New32a()
+	h.Write([]byte(word))
+	return sw.shards[h.Sum32()%wordShardCount]
+}
+
+// incr 原子地增加指定单词在指定分类下的计数
+func (sw *shardedWords) incr(word, category string, n uint64) {
+	s := sw.shardFor(word)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.words[word]; !ok {
+		s.words[word] = make(map[string]uint64)
+	}
+	s.words[word][category] += n
+}
+
+// get 读取指定单词在各分类下计数的一份快照
+func (sw *shardedWords) get(word string) map[string]uint64 {
+	s := sw.shardFor(word)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	counts, ok := s.words[word]
+	if !ok {
+		return nil
+	}
+	out := make(map[string]uint64, len(counts))
+	for category, n := range counts {
+		out[category] = n
+	}
+	return out
+}
+
+// decr 原子地减少指定单词在指定分类下的计数，不会减到 0 以下；
+// 计数归零后连带清理空的分类 / 单词条目
+func (sw *shardedWords) decr(word, category string, n uint64) {
+	s := sw.shardFor(word)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts, ok := s.words[word]
+	if !ok {
+		return
+	}
+	if counts[category] <= n {
+		delete(counts, category)
+	} else {
+		counts[category] -= n
+	}
+	if len(counts) == 0 {
+		delete(s.words, word)
+	}
+}
+
+// deleteCategory 从所有分片中移除指定分类的计数，用于整类删除
+func (sw *shardedWords) deleteCategory(category string) {
+	for _, s := range sw.shards {
+		s.mu.Lock()
+		for word, counts := range s.words {
+			delete(counts, category)
+			if len(counts) == 0 {
+				delete(s.words, word)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// forEach 遍历全部单词及其计数，回调中拿到的 map 是该分片加锁期间的直接引用，
+// 仅供只读统计使用，不应在回调中保留或修改
+func (sw *shardedWords) forEach(fn func(word string, counts map[string]uint64)) {
+	for _, s := range sw.shards {
+		s.mu.RLock()
+		for word, counts := range s.words {
+			fn(word, counts)
+		}
+		s.mu.RUnlock()
+	}
+}
+
+// snapshot 逐个分片在读锁下复制并导出全部单词数据，复制某个分片期间会阻塞
+// 对该分片的写入，不会阻塞其他分片的写入
+func (sw *shardedWords) snapshot() map[string]map[string]float64 {
+	out := make(map[string]map[string]float64)
+	for _, s := range sw.shards {
+		s.mu.RLock()
+		for word, counts := range s.words {
+			wc := make(map[string]float64, len(counts))
+			for category, n := range counts {
+				wc[category] = float64(n)
+			}
+			out[word] = wc
+		}
+		s.mu.RUnlock()
+	}
+	return out
+}
+
+// categoryCounts 用原子计数器记录每个分类训练的文档数，
+// 分类集合本身的增删由读写锁保护，计数的递增与读取无需加锁
+type categoryCounts struct {
+	mu     sync.RWMutex
+	counts map[string]*atomic.Uint64
+}
+
+// newCategoryCounts 实例化一个空的分类计数器
+func newCategoryCounts() *categoryCounts {
+	return &categoryCounts{counts: make(map[string]*atomic.Uint64)}
+}
+
+// incr 原子地增加指定分类的计数，分类首次出现时自动创建
+func (c *categoryCounts) incr(category string, n uint64) {
+	c.mu.RLock()
+	counter, ok := c.counts[category]
+	c.mu.RUnlock()
+	if !ok {
+		c.mu.Lock()
+		counter, ok = c.counts[category]
+		if !ok {
+			counter = new(atomic.Uint64)
+			c.counts[category] = counter
+		}
+		c.mu.Unlock()
+	}
+	counter.Add(n)
+}
+
+// get 读取指定分类当前的计数
+func (c *categoryCounts) get(category string) uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if counter, ok := c.counts[category]; ok {
+		return counter.Load()
+	}
+	return 0
+}
+
+// decr 减少指定分类的计数，不会减到 0 以下；计数归零后连带从集合中删除该
+// 分类，同 shardedWords.decr 一样，避免 categoryNumTotal 被清空的分类污染
+func (c *categoryCounts) decr(category string, n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counter, ok := c.counts[category]
+	if !ok {
+		return
+	}
+	cur := counter.Load()
+	next := uint64(0)
+	if cur > n {
+		next = cur - n
+	}
+	counter.Store(next)
+	if next == 0 {
+		delete(c.counts, category)
+	}
+}
+
+// delete 从分类集合中移除指定分类
+func (c *categoryCounts) delete(category string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.counts, category)
+}
+
+// names 返回当前已存在的所有分类名
+func (c *categoryCounts) names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]string, 0, len(c.counts))
+	for category := range c.counts {
+		out = append(out, category)
+	}
+	return out
+}
+
+// snapshot 导出所有分类当前计数的一份快照
+func (c *categoryCounts) snapshot() map[string]float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[string]float64, len(c.counts))
+	for category, counter := range c.counts {
+		out[category] = float64(counter.Load())
+	}
+	return out
+}
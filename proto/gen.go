@@ -0,0 +1,6 @@
+package proto
+
+// 生成 classifier.pb.go / classifier_grpc.pb.go。需要本机已安装
+// protoc、protoc-gen-go、protoc-gen-go-grpc（参见 README.md），
+// 执行 `go generate ./proto` 或仓库根目录下的 `make proto`。
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative classifier.proto
@@ -0,0 +1,109 @@
+package proto
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/xmdhs/bayesian-classifier/classifier"
+)
+
+// Server 实现 classifier.proto 中定义的 ClassifierServiceServer，
+// 将 gRPC 请求转发给底层的 classifier.Classifier，各方法与
+// classifier.HTTP 的对应 handler 逻辑一致。
+//
+// 本文件依赖 gen.go 中 go:generate 产出的 classifier.pb.go /
+// classifier_grpc.pb.go（ClassifierServiceServer、
+// UnimplementedClassifierServiceServer 及各 *Request/*Response 消息类型），
+// 构建前请先执行 `make proto`
+type Server struct {
+	UnimplementedClassifierServiceServer
+	classifier *classifier.Classifier
+}
+
+// NewServer 实例化一个包装了 Classifier 的 gRPC 服务端
+func NewServer(c *classifier.Classifier) *Server {
+	return &Server{classifier: c}
+}
+
+// Serve 在 addr 上监听并启动 gRPC 服务，会一直阻塞直到服务退出，
+// 用法与 classifier.HTTP.Start 对应：由调用方的 main 决定何时启动
+func Serve(addr string, c *classifier.Classifier) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s := grpc.NewServer()
+	RegisterClassifierServiceServer(s, NewServer(c))
+	return s.Serve(lis)
+}
+
+// Train 处理 Train，批量训练分类器
+func (s *Server) Train(ctx context.Context, req *TrainRequest) (*TrainResponse, error) {
+	docs := make([]classifier.Doc, 0, len(req.Docs))
+	for _, sample := range req.Docs {
+		docs = append(docs, classifier.Doc{Text: sample.Doc, Category: sample.Category})
+	}
+	s.classifier.TrainingBatch(docs)
+	return &TrainResponse{Count: int32(len(docs))}, nil
+}
+
+// Untrain 处理 Untrain，用于纠正错误标注的样本
+func (s *Server) Untrain(ctx context.Context, req *TrainRequest) (*TrainResponse, error) {
+	for _, sample := range req.Docs {
+		s.classifier.Untrain(sample.Doc, sample.Category)
+	}
+	return &TrainResponse{Count: int32(len(req.Docs))}, nil
+}
+
+// Classify 处理 Classify，同时返回朴素贝叶斯与 Fisher 两种打分方式的结果
+func (s *Server) Classify(ctx context.Context, req *ClassifyRequest) (*ClassifyResponse, error) {
+	resp := &ClassifyResponse{}
+	for _, item := range s.classifier.Categorize(req.Doc) {
+		resp.Bayes = append(resp.Bayes, &ScoreItem{Category: item.Category, Score: item.Score})
+	}
+	for _, item := range s.classifier.FisherCategorize(req.Doc) {
+		resp.Fisher = append(resp.Fisher, &ScoreItem{Category: item.Category, Score: item.Score})
+	}
+	return resp, nil
+}
+
+// ListCategories 处理 ListCategories，列出所有分类及其文档数
+func (s *Server) ListCategories(ctx context.Context, _ *Empty) (*CategoriesResponse, error) {
+	return &CategoriesResponse{Categorys: s.classifier.Categorys()}, nil
+}
+
+// RemoveCategory 处理 RemoveCategory，整类撤销训练数据
+func (s *Server) RemoveCategory(ctx context.Context, req *RemoveCategoryRequest) (*Empty, error) {
+	s.classifier.RemoveCategory(req.Category)
+	return &Empty{}, nil
+}
+
+// GetWord 处理 GetWord，返回该单词在各分类下的概率分布
+func (s *Server) GetWord(ctx context.Context, req *GetWordRequest) (*WordResponse, error) {
+	resp := &WordResponse{}
+	for _, item := range s.classifier.Score(req.Word, "") {
+		resp.Distribution = append(resp.Distribution, &ScoreItem{Category: item.Category, Score: item.Score})
+	}
+	return resp, nil
+}
+
+// Snapshot 处理 Snapshot，强制立即导出一次完整训练数据
+func (s *Server) Snapshot(ctx context.Context, _ *Empty) (*Empty, error) {
+	return &Empty{}, s.classifier.Export()
+}
+
+// Stats 处理 Stats，返回词汇量、文档数与各分类的熵
+func (s *Server) Stats(ctx context.Context, _ *Empty) (*StatsResponse, error) {
+	stats := s.classifier.Stats()
+	resp := &StatsResponse{
+		VocabSize: int32(stats.VocabSize),
+		DocCount:  stats.DocCount,
+		Categorys: make(map[string]*CategoryStats, len(stats.Categorys)),
+	}
+	for category, cs := range stats.Categorys {
+		resp.Categorys[category] = &CategoryStats{Docs: cs.Docs, Entropy: cs.Entropy}
+	}
+	return resp, nil
+}
@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// categoriesBucket 存放每个分类的文档计数
+const categoriesBucket = "Categories"
+
+// wordsBucketPrefix 之后每个分类都有独立的 "Words/<category>" bucket 存放该分类下的单词计数，
+// 避免所有分类共用一个 bucket 导致 key 冲突，也便于按分类整体删除（DELETE /v1/categories）
+const wordsBucketPrefix = "Words/"
+
+// thresholdsBucket、minimumsBucket 分别存放 SetThreshold / SetMinimum 设置的
+// 每分类参数，与 Categories、Words/<category> 一样需要随 Save/Load 一起持久化，
+// 否则使用 Bolt 驱动时重启会丢失所有已配置的阈值
+const thresholdsBucket = "Thresholds"
+const minimumsBucket = "Minimums"
+
+// BoltStorage 是基于 BoltDB 的存储引擎，适合词汇量巨大的语料：
+// 训练时可以直接增量更新磁盘上的计数，不需要在每次保存时重新序列化整个模型。
+// 计数以 varint 编码存储，参考了 sisyphus 项目的存储方式
+type BoltStorage struct {
+	db *bolt.DB
+}
+
+// NewBoltStorage 打开（或创建）一个 BoltDB 文件作为存储引擎
+func NewBoltStorage(path string) (*BoltStorage, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(categoriesBucket))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return &BoltStorage{db: db}, nil
+}
+
+// IncrCategory 增量增加指定分类的计数
+func (b *BoltStorage) IncrCategory(category string, n uint64) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(categoriesBucket))
+		cur := decodeUvarint(bucket.Get([]byte(category)))
+		return bucket.Put([]byte(category), encodeUvarint(cur+n))
+	})
+}
+
+// IncrWord 增量增加指定单词在指定分类下的计数
+func (b *BoltStorage) IncrWord(word, category string, n uint64) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(wordsBucketName(category))
+		if err != nil {
+			return err
+		}
+		cur := decodeUvarint(bucket.Get([]byte(word)))
+		return bucket.Put([]byte(word), encodeUvarint(cur+n))
+	})
+}
+
+// Incremental BoltStorage 的 IncrWord/IncrCategory 每次调用都直接写入
+// BoltDB，不需要调用方再定期 Save 做整体导出
+func (b *BoltStorage) Incremental() bool {
+	return true
+}
+
+// GetWord 返回指定单词在各分类下的计数，不存在时返回 nil
+func (b *BoltStorage) GetWord(word string) (map[string]uint64, error) {
+	out := make(map[string]uint64)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			category, ok := categoryFromBucket(name)
+			if !ok {
+				return nil
+			}
+			if v := bucket.Get([]byte(word)); v != nil {
+				out[category] = decodeUvarint(v)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// Iterate 遍历全部单词及其计数，fn 返回 false 时提前终止
+func (b *BoltStorage) Iterate(fn func(word string, counts map[string]uint64) bool) error {
+	words := make(map[string]map[string]uint64)
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			category, ok := categoryFromBucket(name)
+			if !ok {
+				return nil
+			}
+			return bucket.ForEach(func(word, v []byte) error {
+				if _, ok := words[string(word)]; !ok {
+					words[string(word)] = make(map[string]uint64)
+				}
+				words[string(word)][category] = decodeUvarint(v)
+				return nil
+			})
+		})
+	}); err != nil {
+		return err
+	}
+	for word, counts := range words {
+		if !fn(word, counts) {
+			break
+		}
+	}
+	return nil
+}
+
+// Load 读取完整训练数据到 v 指向的结构中，主要用于模型迁移或一次性导出兼容
+func (b *BoltStorage) Load(v interface{}) error {
+	snapshot := fileSnapshot{
+		Categorys:  make(map[string]float64),
+		Words:      make(map[string]map[string]float64),
+		Thresholds: make(map[string]float64),
+		Minimums:   make(map[string]float64),
+	}
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		catBucket := tx.Bucket([]byte(categoriesBucket))
+		if catBucket != nil {
+			if err := catBucket.ForEach(func(k, v []byte) error {
+				snapshot.Categorys[string(k)] = float64(decodeUvarint(v))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		if err := loadFloatBucket(tx, thresholdsBucket, snapshot.Thresholds); err != nil {
+			return err
+		}
+		if err := loadFloatBucket(tx, minimumsBucket, snapshot.Minimums); err != nil {
+			return err
+		}
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			category, ok := categoryFromBucket(name)
+			if !ok {
+				return nil
+			}
+			return bucket.ForEach(func(word, v []byte) error {
+				if _, ok := snapshot.Words[string(word)]; !ok {
+					snapshot.Words[string(word)] = make(map[string]float64)
+				}
+				snapshot.Words[string(word)][category] = float64(decodeUvarint(v))
+				return nil
+			})
+		})
+	}); err != nil {
+		return err
+	}
+
+	b2, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b2, v)
+}
+
+// Save 将 v 指向的完整训练数据写入 BoltDB，完全以快照内容为准：
+// 写入前先清空数据库中已有的全部 bucket，确保 Untrain / RemoveCategory 等
+// 只更新了内存的操作不会在下一次 Export 之后于磁盘上死灰复燃
+func (b *BoltStorage) Save(v interface{}) error {
+	b2, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var snapshot fileSnapshot
+	if err := json.Unmarshal(b2, &snapshot); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := deleteAllBuckets(tx); err != nil {
+			return err
+		}
+
+		catBucket, err := tx.CreateBucketIfNotExists([]byte(categoriesBucket))
+		if err != nil {
+			return err
+		}
+		for category, n := range snapshot.Categorys {
+			if err := catBucket.Put([]byte(category), encodeUvarint(uint64(n))); err != nil {
+				return err
+			}
+		}
+		if err := saveFloatBucket(tx, thresholdsBucket, snapshot.Thresholds); err != nil {
+			return err
+		}
+		if err := saveFloatBucket(tx, minimumsBucket, snapshot.Minimums); err != nil {
+			return err
+		}
+		for word, counts := range snapshot.Words {
+			for category, n := range counts {
+				bucket, err := tx.CreateBucketIfNotExists(wordsBucketName(category))
+				if err != nil {
+					return err
+				}
+				if err := bucket.Put([]byte(word), encodeUvarint(uint64(n))); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// loadFloatBucket 把名为 name 的 bucket 中的全部键值读取到 out 中，bucket 不存在时视为空
+func loadFloatBucket(tx *bolt.Tx, name string, out map[string]float64) error {
+	bucket := tx.Bucket([]byte(name))
+	if bucket == nil {
+		return nil
+	}
+	return bucket.ForEach(func(k, v []byte) error {
+		out[string(k)] = decodeFloat64(v)
+		return nil
+	})
+}
+
+// saveFloatBucket 把 values 整体写入名为 name 的 bucket，不存在则创建
+func saveFloatBucket(tx *bolt.Tx, name string, values map[string]float64) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(name))
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if err := bucket.Put([]byte(key), encodeFloat64(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeFloat64(f float64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, math.Float64bits(f))
+	return buf
+}
+
+func decodeFloat64(b []byte) float64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b))
+}
+
+// deleteAllBuckets 删除数据库中当前存在的全部 top-level bucket，
+// 用于 Save 在写入新快照前先清空旧数据（包括不再出现的单词、整类撤销后的
+// Words/<category> bucket），避免撤销操作在磁盘上被遗留数据复活
+func deleteAllBuckets(tx *bolt.Tx) error {
+	var names [][]byte
+	if err := tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+		names = append(names, append([]byte(nil), name...))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := tx.DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func wordsBucketName(category string) []byte {
+	return []byte(fmt.Sprintf("%s%s", wordsBucketPrefix, category))
+}
+
+func categoryFromBucket(name []byte) (string, bool) {
+	if len(name) <= len(wordsBucketPrefix) || string(name[:len(wordsBucketPrefix)]) != wordsBucketPrefix {
+		return "", false
+	}
+	return string(name[len(wordsBucketPrefix):]), true
+}
+
+func encodeUvarint(n uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	l := binary.PutUvarint(buf, n)
+	return buf[:l]
+}
+
+func decodeUvarint(b []byte) uint64 {
+	if len(b) == 0 {
+		return 0
+	}
+	n, _ := binary.Uvarint(b)
+	return n
+}
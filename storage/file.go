@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// fileSnapshot 是存储引擎在内存中维护的读取缓存，也是 BoltStorage 与通用
+// JSON 结构之间转换时使用的中间结构，字段需与 classifier.data 的 JSON 结构
+// 保持完全一致，否则往返转换会丢字段
+type fileSnapshot struct {
+	Categorys  map[string]float64            `json:"category"`
+	Words      map[string]map[string]float64 `json:"words"`
+	Thresholds map[string]float64            `json:"thresholds"`
+	Minimums   map[string]float64            `json:"minimums"`
+}
+
+// FileStorage 是最简单的存储引擎，每次 Save 都会把完整的训练数据序列化为
+// 一个 JSON 文件，Load 时整体读回，适合词汇量不大的场景
+type FileStorage struct {
+	mu   sync.RWMutex
+	path string
+	last fileSnapshot
+}
+
+// NewFileStorage 实例化一个基于 JSON 文件的存储引擎
+func NewFileStorage(path string) (*FileStorage, error) {
+	return &FileStorage{path: path}, nil
+}
+
+// Load 从文件中读取完整训练数据到 v 指向的结构中，文件不存在时视为空数据
+func (f *FileStorage) Load(v interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	json.Unmarshal(b, &f.last)
+	return nil
+}
+
+// Save 将 v 指向的完整训练数据序列化并整体写入文件
+func (f *FileStorage) Save(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.WriteFile(f.path, b, 0644); err != nil {
+		return err
+	}
+	json.Unmarshal(b, &f.last)
+	return nil
+}
+
+// IncrWord 文件存储没有高效的增量写入路径，这里只更新内存中的读取缓存，
+// 真正落盘仍依赖下一次 Save；需要增量持久化请使用 BoltStorage
+func (f *FileStorage) IncrWord(word, category string, n uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.last.Words == nil {
+		f.last.Words = make(map[string]map[string]float64)
+	}
+	if _, ok := f.last.Words[word]; !ok {
+		f.last.Words[word] = make(map[string]float64)
+	}
+	f.last.Words[word][category] += float64(n)
+	return nil
+}
+
+// IncrCategory 同 IncrWord，仅更新内存中的读取缓存
+func (f *FileStorage) IncrCategory(category string, n uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.last.Categorys == nil {
+		f.last.Categorys = make(map[string]float64)
+	}
+	f.last.Categorys[category] += float64(n)
+	return nil
+}
+
+// Incremental 文件存储的 Incr* 只更新内存缓存，没有真正落盘，
+// 仍然需要调用方定期 Save 才能持久化
+func (f *FileStorage) Incremental() bool {
+	return false
+}
+
+// GetWord 返回最近一次 Load/Save 缓存中指定单词的计数
+func (f *FileStorage) GetWord(word string) (map[string]uint64, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	counts, ok := f.last.Words[word]
+	if !ok {
+		return nil, nil
+	}
+	out := make(map[string]uint64, len(counts))
+	for category, n := range counts {
+		out[category] = uint64(n)
+	}
+	return out, nil
+}
+
+// Iterate 遍历最近一次 Load/Save 缓存中的全部单词
+func (f *FileStorage) Iterate(fn func(word string, counts map[string]uint64) bool) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for word, counts := range f.last.Words {
+		out := make(map[string]uint64, len(counts))
+		for category, n := range counts {
+			out[category] = uint64(n)
+		}
+		if !fn(word, out) {
+			break
+		}
+	}
+	return nil
+}
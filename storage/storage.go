@@ -0,0 +1,24 @@
+// Package storage 提供分类器训练数据的持久化实现。
+package storage
+
+// Storage 是训练数据存储引擎需要实现的接口。
+// Load / Save 提供整份训练数据的导入导出，Incr* 系列方法支持增量写入，
+// 使得体量较大的语料不必在每次自动保存时都重新序列化整个模型
+type Storage interface {
+	// Load 读取完整的训练数据到 v 指向的结构中
+	Load(v interface{}) error
+	// Save 将 v 指向的完整训练数据持久化
+	Save(v interface{}) error
+	// IncrWord 增量增加指定单词在指定分类下的计数
+	IncrWord(word, category string, n uint64) error
+	// IncrCategory 增量增加指定分类的计数
+	IncrCategory(category string, n uint64) error
+	// GetWord 读取指定单词在各分类下的计数，不存在时返回 nil
+	GetWord(word string) (map[string]uint64, error)
+	// Iterate 遍历所有单词及其计数，fn 返回 false 时提前终止遍历
+	Iterate(fn func(word string, counts map[string]uint64) bool) error
+	// Incremental 返回 Incr* 系列方法是否已经把数据落盘：为 true 时调用方
+	// 不需要再定期调用 Save 做整体导出，只需要在真正需要完整快照时
+	// （如迁移、备份）手动触发一次
+	Incremental() bool
+}
@@ -0,0 +1,24 @@
+package util
+
+import "github.com/go-ego/gse"
+
+// GseSegmenter 是基于纯 Go 实现的 gse 分词器，无需 CGO 即可运行，
+// 词典可以从文件加载，也可以为空以使用内嵌的默认词典
+type GseSegmenter struct {
+	segmenter gse.Segmenter
+}
+
+// NewGseSegmenter 实例化一个基于 gse 的分词器，dictPaths 为空时使用内嵌默认词典
+func NewGseSegmenter(dictPaths ...string) (*GseSegmenter, error) {
+	seg, err := gse.New(dictPaths...)
+	if err != nil {
+		return nil, err
+	}
+	return &GseSegmenter{segmenter: seg}, nil
+}
+
+// Segment 分词，开启 HMM 以识别未登录词
+func (t *GseSegmenter) Segment(text string) []string {
+	output := t.segmenter.CutSearch(text, true)
+	return filterWord(output)
+}
@@ -0,0 +1,19 @@
+package util
+
+import "github.com/yanyiwu/gojieba"
+
+// JiebaSegmenter 是基于 gojieba（CGO）实现的分词器，需要在运行环境中提供词典文件
+type JiebaSegmenter struct {
+	segmenter *gojieba.Jieba
+}
+
+// NewJiebaSegmenter 实例化一个基于 gojieba 的分词器
+func NewJiebaSegmenter(dictPath, hmmPath, userDictPath, idfPath, stopWordsPath string) *JiebaSegmenter {
+	return &JiebaSegmenter{segmenter: gojieba.NewJieba(dictPath, hmmPath, userDictPath, idfPath, stopWordsPath)}
+}
+
+// Segment 分词
+func (t *JiebaSegmenter) Segment(text string) []string {
+	output := t.segmenter.CutForSearch(text, true)
+	return filterWord(output)
+}
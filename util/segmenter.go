@@ -1,26 +1,14 @@
 package util
 
-import (
-	"strings"
+import "strings"
 
-	"github.com/yanyiwu/gojieba"
-)
-
-type Segmenter struct {
-	segmenter *gojieba.Jieba
-}
-
-func NewSegmenter() *Segmenter {
-	return &Segmenter{segmenter: gojieba.NewJieba(`dict/jieba.dict.utf8`, `dict/hmm_model.utf8`, `dict/user.dict.utf8`, `dict/idf.utf8`, `dict/stop_words.utf8`)}
-}
-
-// 分词
-func (t *Segmenter) Segment(text string) []string {
-	output := t.segmenter.CutForSearch(text, true)
-	return filterWord(output)
+// Tokenizer 是分词器需要实现的接口，使得具体分词实现可以被替换或由调用方注入，
+// 例如替换为不依赖 CGO 的实现，或用于非中文语料、测试场景
+type Tokenizer interface {
+	Segment(text string) []string
 }
 
-// 过滤干扰词 空格，单字词
+// filterWord 过滤干扰词 空格，单字词
 func filterWord(ws []string) []string {
 	result := make([]string, 0)
 	for _, w := range ws {
@@ -31,3 +19,17 @@ func filterWord(ws []string) []string {
 	}
 	return result
 }
+
+// WhitespaceTokenizer 按空白字符切分，不做任何分词处理
+// 适用于非中文语料，或测试中需要可预测分词结果的场景
+type WhitespaceTokenizer struct{}
+
+// NewWhitespaceTokenizer 实例化一个按空白字符分词的分词器
+func NewWhitespaceTokenizer() *WhitespaceTokenizer {
+	return &WhitespaceTokenizer{}
+}
+
+// Segment 按空白字符切分
+func (t *WhitespaceTokenizer) Segment(text string) []string {
+	return strings.Fields(text)
+}